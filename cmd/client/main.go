@@ -0,0 +1,43 @@
+// Command client — небольшой CLI-клиент для ручной проверки ParcelService.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/Aleksandr-Osipenko/go-db-sql-final/pb"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:9090", "адрес gRPC-сервера")
+	client := flag.Int64("client", 1000, "идентификатор клиента для регистрации посылки")
+	address := flag.String("address", "test", "адрес доставки")
+	flag.Parse()
+
+	conn, err := grpc.NewClient(*addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("dial %s: %v", *addr, err)
+	}
+	defer conn.Close()
+
+	svc := pb.NewParcelServiceClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := svc.Register(ctx, &pb.RegisterRequest{
+		Client:    *client,
+		Address:   *address,
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		log.Fatalf("register: %v", err)
+	}
+
+	log.Printf("registered parcel number=%d", resp.GetNumber())
+}
@@ -0,0 +1,58 @@
+// Command server запускает gRPC-сервер трекера посылок поверх SQLite или PostgreSQL.
+package main
+
+import (
+	"database/sql"
+	"flag"
+	"log"
+	"net"
+
+	_ "github.com/lib/pq"
+	"google.golang.org/grpc"
+	_ "modernc.org/sqlite"
+
+	"github.com/Aleksandr-Osipenko/go-db-sql-final/internal/interceptor"
+	"github.com/Aleksandr-Osipenko/go-db-sql-final/internal/service"
+	"github.com/Aleksandr-Osipenko/go-db-sql-final/internal/store"
+	"github.com/Aleksandr-Osipenko/go-db-sql-final/pb"
+)
+
+func main() {
+	addr := flag.String("addr", ":9090", "адрес, на котором слушает gRPC-сервер")
+	driver := flag.String("driver", string(store.DriverSQLite), "драйвер СУБД: sqlite или postgres")
+	dsn := flag.String("dsn", "tracker.db", "DSN для подключения к БД")
+	flag.Parse()
+
+	sqlDriver := "sqlite"
+	if store.Driver(*driver) == store.DriverPostgres {
+		sqlDriver = "postgres"
+	}
+
+	db, err := sql.Open(sqlDriver, *dsn)
+	if err != nil {
+		log.Fatalf("open db: %v", err)
+	}
+	defer db.Close()
+
+	lis, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatalf("listen %s: %v", *addr, err)
+	}
+
+	parcelServer, err := service.NewParcelServer(store.Config{
+		Driver: store.Driver(*driver),
+		DB:     db,
+		DSN:    *dsn,
+	})
+	if err != nil {
+		log.Fatalf("new parcel server: %v", err)
+	}
+
+	srv := grpc.NewServer(grpc.UnaryInterceptor(interceptor.Logging))
+	pb.RegisterParcelServiceServer(srv, parcelServer)
+
+	log.Printf("parcel service (%s) listening on %s", *driver, *addr)
+	if err := srv.Serve(lis); err != nil {
+		log.Fatalf("serve: %v", err)
+	}
+}
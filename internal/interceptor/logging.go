@@ -0,0 +1,18 @@
+// Package interceptor содержит gRPC-перехватчики, общие для сервера трекера посылок.
+package interceptor
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// Logging логирует каждый унарный вызов: метод, время выполнения и ошибку, если она есть.
+func Logging(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	log.Printf("method=%s duration=%s err=%v", info.FullMethod, time.Since(start), err)
+	return resp, err
+}
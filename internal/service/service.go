@@ -0,0 +1,150 @@
+// Package service содержит реализацию gRPC-сервиса ParcelService поверх store.ParcelStore.
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Aleksandr-Osipenko/go-db-sql-final/internal/store"
+	"github.com/Aleksandr-Osipenko/go-db-sql-final/pb"
+)
+
+// ParcelServer реализует pb.ParcelServiceServer поверх store.ParcelStore.
+// Он владеет подключением к БД и создаёт ParcelStore для его использования.
+type ParcelServer struct {
+	pb.UnimplementedParcelServiceServer
+
+	db    *sql.DB
+	store store.ParcelStore
+}
+
+// NewParcelServer создаёт gRPC-сервис поверх ParcelStore, настроенного согласно cfg.
+func NewParcelServer(cfg store.Config) (*ParcelServer, error) {
+	s, err := store.NewParcelStore(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &ParcelServer{db: cfg.DB, store: s}, nil
+}
+
+func (s *ParcelServer) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.RegisterResponse, error) {
+	if req.GetClient() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "client must be positive")
+	}
+	if req.GetAddress() == "" {
+		return nil, status.Error(codes.InvalidArgument, "address is required")
+	}
+
+	number, err := s.store.Add(ctx, store.Parcel{
+		Client:    int(req.GetClient()),
+		Status:    store.ParcelStatusRegistered,
+		Address:   req.GetAddress(),
+		CreatedAt: req.GetCreatedAt(),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "add parcel: %v", err)
+	}
+
+	return &pb.RegisterResponse{Number: int64(number)}, nil
+}
+
+func (s *ParcelServer) Get(ctx context.Context, req *pb.GetRequest) (*pb.GetResponse, error) {
+	if req.GetNumber() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "number must be positive")
+	}
+
+	p, err := s.store.Get(ctx, int(req.GetNumber()))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Errorf(codes.NotFound, "parcel %d not found", req.GetNumber())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "get parcel: %v", err)
+	}
+
+	return &pb.GetResponse{Parcel: toPBParcel(p)}, nil
+}
+
+func (s *ParcelServer) ListByClient(ctx context.Context, req *pb.ListByClientRequest) (*pb.ListByClientResponse, error) {
+	if req.GetClient() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "client must be positive")
+	}
+
+	parcels, err := s.store.GetByClient(ctx, int(req.GetClient()))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list parcels: %v", err)
+	}
+
+	resp := &pb.ListByClientResponse{Parcels: make([]*pb.Parcel, 0, len(parcels))}
+	for _, p := range parcels {
+		resp.Parcels = append(resp.Parcels, toPBParcel(p))
+	}
+	return resp, nil
+}
+
+func (s *ParcelServer) UpdateStatus(ctx context.Context, req *pb.UpdateStatusRequest) (*pb.UpdateStatusResponse, error) {
+	if req.GetNumber() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "number must be positive")
+	}
+
+	err := s.store.SetStatus(ctx, int(req.GetNumber()), store.ParcelStatus(req.GetStatus()))
+	switch {
+	case errors.Is(err, store.ErrParcelNotFound):
+		return nil, status.Errorf(codes.NotFound, "parcel %d not found", req.GetNumber())
+	case errors.Is(err, store.ErrInvalidStatusTransition):
+		return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "set status: %v", err)
+	}
+	return &pb.UpdateStatusResponse{}, nil
+}
+
+func (s *ParcelServer) UpdateAddress(ctx context.Context, req *pb.UpdateAddressRequest) (*pb.UpdateAddressResponse, error) {
+	if req.GetNumber() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "number must be positive")
+	}
+	if req.GetAddress() == "" {
+		return nil, status.Error(codes.InvalidArgument, "address is required")
+	}
+
+	err := s.store.SetAddress(ctx, int(req.GetNumber()), req.GetAddress())
+	switch {
+	case errors.Is(err, store.ErrParcelNotFound):
+		return nil, status.Errorf(codes.NotFound, "parcel %d not found", req.GetNumber())
+	case errors.Is(err, store.ErrParcelNotEditable):
+		return nil, status.Errorf(codes.FailedPrecondition, "parcel %d is not editable", req.GetNumber())
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "set address: %v", err)
+	}
+	return &pb.UpdateAddressResponse{}, nil
+}
+
+func (s *ParcelServer) Delete(ctx context.Context, req *pb.DeleteRequest) (*pb.DeleteResponse, error) {
+	if req.GetNumber() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "number must be positive")
+	}
+
+	err := s.store.Delete(ctx, int(req.GetNumber()))
+	switch {
+	case errors.Is(err, store.ErrParcelNotFound):
+		return nil, status.Errorf(codes.NotFound, "parcel %d not found", req.GetNumber())
+	case errors.Is(err, store.ErrParcelNotEditable):
+		return nil, status.Errorf(codes.FailedPrecondition, "parcel %d is not editable", req.GetNumber())
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "delete parcel: %v", err)
+	}
+	return &pb.DeleteResponse{}, nil
+}
+
+func toPBParcel(p store.Parcel) *pb.Parcel {
+	return &pb.Parcel{
+		Number:    int64(p.Number),
+		Client:    int64(p.Client),
+		Status:    string(p.Status),
+		Address:   p.Address,
+		CreatedAt: p.CreatedAt,
+	}
+}
@@ -0,0 +1,113 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	_ "modernc.org/sqlite"
+
+	"github.com/Aleksandr-Osipenko/go-db-sql-final/internal/service"
+	"github.com/Aleksandr-Osipenko/go-db-sql-final/internal/store"
+	"github.com/Aleksandr-Osipenko/go-db-sql-final/pb"
+)
+
+const bufSize = 1024 * 1024
+
+// newTestClient поднимает ParcelService поверх временной БД и bufconn-слушателя,
+// возвращая клиента, готового к вызовам, и функцию для остановки сервера.
+func newTestClient(t *testing.T) (pb.ParcelServiceClient, func()) {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", "file:"+t.TempDir()+"/tracker.db")
+	require.NoError(t, err)
+
+	if _, err := db.Exec(`CREATE TABLE parcel (
+		number INTEGER PRIMARY KEY AUTOINCREMENT,
+		client INTEGER,
+		status TEXT,
+		address TEXT,
+		created_at TEXT
+	)`); err != nil {
+		require.NoError(t, err)
+	}
+
+	parcelServer, err := service.NewParcelServer(store.Config{Driver: store.DriverSQLite, DB: db})
+	require.NoError(t, err)
+
+	lis := bufconn.Listen(bufSize)
+	srv := grpc.NewServer()
+	pb.RegisterParcelServiceServer(srv, parcelServer)
+	go srv.Serve(lis)
+
+	dialer := func(context.Context, string) (net.Conn, error) { return lis.Dial() }
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(dialer),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+
+	cleanup := func() {
+		conn.Close()
+		srv.Stop()
+		db.Close()
+	}
+
+	return pb.NewParcelServiceClient(conn), cleanup
+}
+
+// TestParcelServiceRegisterGetDelete проверяет основной сценарий работы через gRPC:
+// регистрация посылки, чтение и удаление.
+func TestParcelServiceRegisterGetDelete(t *testing.T) {
+	client, cleanup := newTestClient(t)
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	registered, err := client.Register(ctx, &pb.RegisterRequest{
+		Client:    1000,
+		Address:   "test",
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+	})
+	require.NoError(t, err)
+	require.NotZero(t, registered.GetNumber())
+
+	got, err := client.Get(ctx, &pb.GetRequest{Number: registered.GetNumber()})
+	require.NoError(t, err)
+	require.Equal(t, "test", got.GetParcel().GetAddress())
+
+	_, err = client.Delete(ctx, &pb.DeleteRequest{Number: registered.GetNumber()})
+	require.NoError(t, err)
+
+	_, err = client.Get(ctx, &pb.GetRequest{Number: registered.GetNumber()})
+	require.Error(t, err)
+}
+
+// TestParcelServiceListByClient проверяет получение посылок по клиенту через gRPC.
+func TestParcelServiceListByClient(t *testing.T) {
+	client, cleanup := newTestClient(t)
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	const clientID = int64(2000)
+	for i := 0; i < 3; i++ {
+		_, err := client.Register(ctx, &pb.RegisterRequest{
+			Client:    clientID,
+			Address:   "test",
+			CreatedAt: time.Now().UTC().Format(time.RFC3339),
+		})
+		require.NoError(t, err)
+	}
+
+	list, err := client.ListByClient(ctx, &pb.ListByClientRequest{Client: clientID})
+	require.NoError(t, err)
+	require.Len(t, list.GetParcels(), 3)
+}
@@ -0,0 +1,20 @@
+package store
+
+import "errors"
+
+var (
+	// ErrParcelNotFound возвращается из SetStatus, SetAddress и Delete, когда
+	// посылка с указанным номером не найдена. Get в этом случае возвращает
+	// sql.ErrNoRows.
+	ErrParcelNotFound = errors.New("store: parcel not found")
+
+	// ErrParcelNotEditable возвращается из SetAddress и Delete, когда посылка
+	// существует, но её статус отличен от registered, и поэтому не может быть
+	// изменена или удалена.
+	ErrParcelNotEditable = errors.New("store: parcel is not editable")
+
+	// ErrInvalidStatusTransition возвращается из SetStatus, когда запрошенный
+	// переход между статусами не входит в число разрешённых
+	// (registered -> sent -> delivered, без возврата назад).
+	ErrInvalidStatusTransition = errors.New("store: invalid parcel status transition")
+)
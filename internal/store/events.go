@@ -0,0 +1,22 @@
+package store
+
+import "time"
+
+// ParcelEvent описывает изменение жизненного цикла посылки: регистрацию или
+// смену статуса. При регистрации OldStatus пуст.
+type ParcelEvent struct {
+	Number    int
+	OldStatus ParcelStatus
+	NewStatus ParcelStatus
+	Timestamp time.Time
+}
+
+// ParcelEventFilter отбирает события, интересующие конкретного подписчика.
+// Нулевое значение ClientID означает "события всех клиентов".
+type ParcelEventFilter struct {
+	ClientID int
+}
+
+func (f ParcelEventFilter) matches(clientID int) bool {
+	return f.ClientID == 0 || f.ClientID == clientID
+}
@@ -0,0 +1,41 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fixtureParcels — известный набор посылок одного клиента, используемый тестами
+// GetByClient. Значения зафиксированы, чтобы тест не зависел от случайных данных.
+func fixtureParcels(client int) []Parcel {
+	createdAt := time.Date(2024, time.January, 2, 15, 4, 5, 0, time.UTC).Format(time.RFC3339)
+	return []Parcel{
+		{Client: client, Status: ParcelStatusRegistered, Address: "Moscow, Lenina 1", CreatedAt: createdAt},
+		{Client: client, Status: ParcelStatusRegistered, Address: "Saint Petersburg, Nevsky 10", CreatedAt: createdAt},
+		{Client: client, Status: ParcelStatusRegistered, Address: "Novosibirsk, Krasny 5", CreatedAt: createdAt},
+	}
+}
+
+// seedFixtures добавляет fixtureParcels(client) в store и возвращает их
+// с заполненными Number, а также map для поиска по номеру.
+func seedFixtures(ctx context.Context, t *testing.T, store ParcelStore, client int) (parcels []Parcel, byNumber map[int]Parcel) {
+	t.Helper()
+
+	parcels = fixtureParcels(client)
+	byNumber = make(map[int]Parcel, len(parcels))
+
+	for i, p := range parcels {
+		number, err := store.Add(ctx, p)
+		require.NoError(t, err)
+		require.NotEmpty(t, number)
+
+		p.Number = number
+		parcels[i] = p
+		byNumber[number] = p
+	}
+
+	return parcels, byNumber
+}
@@ -0,0 +1,29 @@
+package store
+
+import (
+	_ "embed"
+	"os"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+// schemaSQL содержит DDL таблицы parcel для SQLite. Каждый тест открывает
+// собственную временную БД (см. newSQLiteStore в sqlite_test.go) и применяет
+// эту схему, поэтому тесты не делят состояние и не зависят от порядка запуска.
+//
+//go:embed testdata/schema.sql
+var schemaSQL string
+
+// postgresSchemaSQL — та же схема в диалекте PostgreSQL (см. newPostgresStore
+// в postgres_test.go, собирается только с тегом postgres).
+//
+//go:embed testdata/postgres_schema.sql
+var postgresSchemaSQL string
+
+// TestMain — единая точка входа тестов пакета store. Инициализация БД для
+// каждого теста выполняется локально в его собственной временной базе, а не
+// здесь, поэтому TestMain просто запускает тесты.
+func TestMain(m *testing.M) {
+	os.Exit(m.Run())
+}
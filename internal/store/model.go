@@ -0,0 +1,32 @@
+package store
+
+// ParcelStatus описывает текущее состояние посылки в трекере.
+type ParcelStatus string
+
+const (
+	ParcelStatusRegistered ParcelStatus = "registered"
+	ParcelStatusSent       ParcelStatus = "sent"
+	ParcelStatusDelivered  ParcelStatus = "delivered"
+)
+
+// nextAllowedStatus описывает разрешённые переходы между статусами посылки:
+// registered -> sent -> delivered, без возможности вернуться назад.
+var nextAllowedStatus = map[ParcelStatus]ParcelStatus{
+	ParcelStatusRegistered: ParcelStatusSent,
+	ParcelStatusSent:       ParcelStatusDelivered,
+}
+
+// canTransition сообщает, разрешён ли переход посылки из from в to.
+func canTransition(from, to ParcelStatus) bool {
+	next, ok := nextAllowedStatus[from]
+	return ok && next == to
+}
+
+// Parcel описывает посылку, которую отслеживает трекер.
+type Parcel struct {
+	Number    int
+	Client    int
+	Status    ParcelStatus
+	Address   string
+	CreatedAt string
+}
@@ -0,0 +1,260 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/Aleksandr-Osipenko/go-db-sql-final/internal/tracing"
+)
+
+// PostgresStore реализует ParcelStore поверх PostgreSQL.
+type PostgresStore struct {
+	db  *sql.DB
+	dsn string // используется отдельно от db для LISTEN/NOTIFY в Subscribe
+}
+
+// NewPostgresStore создаёт ParcelStore поверх PostgreSQL. dsn нужен отдельно
+// от db, поскольку LISTEN/NOTIFY в Subscribe требует собственного "сырого"
+// подключения через pq.Listener, а не пула database/sql.
+func NewPostgresStore(db *sql.DB, dsn string) *PostgresStore {
+	return &PostgresStore{db: db, dsn: dsn}
+}
+
+func (s *PostgresStore) Add(ctx context.Context, p Parcel) (int, error) {
+	ctx, span := tracing.StartSpanFromContext(ctx, "PostgresStore.Add",
+		attribute.Int("parcel.client", p.Client),
+		attribute.String("parcel.status", string(p.Status)),
+	)
+	defer span.End()
+
+	var number int
+	err := s.db.QueryRowContext(ctx,
+		"INSERT INTO parcel (client, status, address, created_at) VALUES ($1, $2, $3, $4) RETURNING number",
+		p.Client, p.Status, p.Address, p.CreatedAt,
+	).Scan(&number)
+	if err != nil {
+		return 0, err
+	}
+	return number, nil
+}
+
+func (s *PostgresStore) Get(ctx context.Context, number int) (Parcel, error) {
+	ctx, span := tracing.StartSpanFromContext(ctx, "PostgresStore.Get", attribute.Int("parcel.number", number))
+	defer span.End()
+
+	row := s.db.QueryRowContext(ctx, "SELECT number, client, status, address, created_at FROM parcel WHERE number = $1", number)
+
+	p := Parcel{}
+	err := row.Scan(&p.Number, &p.Client, &p.Status, &p.Address, &p.CreatedAt)
+	if err != nil {
+		return p, err
+	}
+	return p, nil
+}
+
+func (s *PostgresStore) GetByClient(ctx context.Context, client int) ([]Parcel, error) {
+	ctx, span := tracing.StartSpanFromContext(ctx, "PostgresStore.GetByClient", attribute.Int("parcel.client", client))
+	defer span.End()
+
+	rows, err := s.db.QueryContext(ctx, "SELECT number, client, status, address, created_at FROM parcel WHERE client = $1", client)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []Parcel
+	for rows.Next() {
+		p := Parcel{}
+		err := rows.Scan(&p.Number, &p.Client, &p.Status, &p.Address, &p.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, p)
+	}
+
+	return res, rows.Err()
+}
+
+// SetStatus переводит посылку в новый статус, проверяя, что переход разрешён
+// (registered -> sent -> delivered, без возврата назад). Текущая строка
+// блокируется SELECT ... FOR UPDATE в рамках одной транзакции, чтобы исключить
+// гонку между конкурентными переходами одной и той же посылки.
+func (s *PostgresStore) SetStatus(ctx context.Context, number int, newStatus ParcelStatus) error {
+	ctx, span := tracing.StartSpanFromContext(ctx, "PostgresStore.SetStatus",
+		attribute.Int("parcel.number", number),
+		attribute.String("parcel.status", string(newStatus)),
+	)
+	defer span.End()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var current ParcelStatus
+	err = tx.QueryRowContext(ctx, "SELECT status FROM parcel WHERE number = $1 FOR UPDATE", number).Scan(&current)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrParcelNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if !canTransition(current, newStatus) {
+		return fmt.Errorf("%w: %s -> %s", ErrInvalidStatusTransition, current, newStatus)
+	}
+
+	if _, err := tx.ExecContext(ctx, "UPDATE parcel SET status = $1 WHERE number = $2", newStatus, number); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SetAddress меняет адрес посылки, только если она ещё в статусе registered.
+// Проверка и UPDATE выполняются в рамках одной транзакции с SELECT ... FOR
+// UPDATE, как и в SetStatus, — иначе конкурентный SetStatus мог бы перевести
+// посылку в другой статус между проверкой и UPDATE, и запрос молча обновил
+// бы 0 строк.
+func (s *PostgresStore) SetAddress(ctx context.Context, number int, address string) error {
+	ctx, span := tracing.StartSpanFromContext(ctx, "PostgresStore.SetAddress", attribute.Int("parcel.number", number))
+	defer span.End()
+
+	return s.withEditableParcel(ctx, number, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, "UPDATE parcel SET address = $1 WHERE number = $2", address, number)
+		return err
+	})
+}
+
+// Delete удаляет посылку, только если она ещё в статусе registered. Проверка
+// и DELETE выполняются в рамках одной транзакции — по тем же причинам, что
+// и в SetAddress.
+func (s *PostgresStore) Delete(ctx context.Context, number int) error {
+	ctx, span := tracing.StartSpanFromContext(ctx, "PostgresStore.Delete", attribute.Int("parcel.number", number))
+	defer span.End()
+
+	return s.withEditableParcel(ctx, number, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, "DELETE FROM parcel WHERE number = $1", number)
+		return err
+	})
+}
+
+// withEditableParcel открывает транзакцию, блокирует строку number через
+// SELECT ... FOR UPDATE, проверяет, что посылка существует и находится в
+// статусе registered, и в рамках той же транзакции выполняет do. Так проверка
+// статуса и изменение строки атомарны относительно конкурентного SetStatus.
+func (s *PostgresStore) withEditableParcel(ctx context.Context, number int, do func(tx *sql.Tx) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var status ParcelStatus
+	err = tx.QueryRowContext(ctx, "SELECT status FROM parcel WHERE number = $1 FOR UPDATE", number).Scan(&status)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrParcelNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if status != ParcelStatusRegistered {
+		return ErrParcelNotEditable
+	}
+
+	if err := do(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// pgNotifyChannel — канал NOTIFY, в который триггеры postgres_schema.sql пишут
+// изменения таблицы parcel.
+const pgNotifyChannel = "parcel_events"
+
+// pgEventPayload — формат JSON, который кладёт в NOTIFY функция notify_parcel_event
+// из postgres_schema.sql.
+type pgEventPayload struct {
+	Number    int    `json:"number"`
+	Client    int    `json:"client"`
+	OldStatus string `json:"old_status"`
+	NewStatus string `json:"new_status"`
+	CreatedAt string `json:"created_at"`
+}
+
+// Subscribe подписывает вызывающую сторону на события посылок, отобранные
+// filter, через LISTEN/NOTIFY PostgreSQL. Канал закрывается при отмене ctx
+// или при неустранимой ошибке слушателя.
+func (s *PostgresStore) Subscribe(ctx context.Context, filter ParcelEventFilter) <-chan ParcelEvent {
+	out := make(chan ParcelEvent, 16)
+
+	listener := pq.NewListener(s.dsn, 10*time.Second, time.Minute, nil)
+
+	// LISTEN регистрируется синхронно, до возврата канала вызывающей стороне:
+	// иначе NOTIFY, отправленный сразу после Subscribe (например, последующим
+	// Add), может быть потерян — у LISTEN/NOTIFY нет буфера предыстории.
+	if err := listener.Listen(pgNotifyChannel); err != nil {
+		close(out)
+		listener.Close()
+		return out
+	}
+
+	go func() {
+		defer close(out)
+		defer listener.Close()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case n, ok := <-listener.Notify:
+				if !ok {
+					return
+				}
+				if n == nil {
+					// listener переподключился сам; пропущенные за это время
+					// уведомления NOTIFY не переигрывает.
+					continue
+				}
+
+				var payload pgEventPayload
+				if err := json.Unmarshal([]byte(n.Extra), &payload); err != nil {
+					continue
+				}
+				if !filter.matches(payload.Client) {
+					continue
+				}
+
+				ts, err := time.Parse(time.RFC3339, payload.CreatedAt)
+				if err != nil {
+					ts = time.Now().UTC()
+				}
+
+				event := ParcelEvent{
+					Number:    payload.Number,
+					OldStatus: ParcelStatus(payload.OldStatus),
+					NewStatus: ParcelStatus(payload.NewStatus),
+					Timestamp: ts,
+				}
+
+				select {
+				case out <- event:
+				default:
+					// подписчик не успевает вычитывать — не блокируем listener.
+				}
+			}
+		}
+	}()
+
+	return out
+}
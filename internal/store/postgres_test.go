@@ -0,0 +1,96 @@
+//go:build postgres
+
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var nonAlnum = regexp.MustCompile(`[^a-zA-Z0-9]+`)
+
+// newPostgresStore открывает тестовое подключение к PostgreSQL для прогонки общих
+// сценариев. DSN берётся из PARCEL_POSTGRES_DSN (см. docker-compose.yml). Каждый
+// тест получает собственную Postgres-схему, поэтому тесты не делят состояние и
+// не зависят от порядка запуска.
+func newPostgresStore(t *testing.T) ParcelStore {
+	t.Helper()
+
+	dsn := os.Getenv("PARCEL_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("PARCEL_POSTGRES_DSN is not set")
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	require.NoError(t, err) //тест на ошибку подключения к БД
+	t.Cleanup(func() { db.Close() })
+
+	// SET search_path ниже действует только на подключение, которое его выполнило,
+	// поэтому держим ровно одно соединение на тест.
+	db.SetMaxOpenConns(1)
+
+	schema := "test_" + nonAlnum.ReplaceAllString(t.Name(), "_")
+
+	_, err = db.Exec(fmt.Sprintf("CREATE SCHEMA %s", schema))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		db.Exec(fmt.Sprintf("DROP SCHEMA %s CASCADE", schema))
+	})
+
+	_, err = db.Exec(fmt.Sprintf("SET search_path TO %s", schema))
+	require.NoError(t, err)
+
+	_, err = db.Exec(postgresSchemaSQL)
+	require.NoError(t, err) //тест на ошибку применения схемы
+
+	return NewPostgresStore(db, dsn)
+}
+
+func TestPostgresAddGetDelete(t *testing.T) {
+	testAddGetDelete(t, newPostgresStore(t))
+}
+
+func TestPostgresSetAddress(t *testing.T) {
+	testSetAddress(t, newPostgresStore(t))
+}
+
+func TestPostgresSetStatus(t *testing.T) {
+	testSetStatus(t, newPostgresStore(t))
+}
+
+func TestPostgresGetByClient(t *testing.T) {
+	testGetByClient(t, newPostgresStore(t))
+}
+
+func TestPostgresGetByClientContextCancelled(t *testing.T) {
+	testGetByClientContextCancelled(t, newPostgresStore(t))
+}
+
+func TestPostgresSetStatusInvalidTransition(t *testing.T) {
+	testSetStatusInvalidTransition(t, newPostgresStore(t))
+}
+
+func TestPostgresSetAddressNotEditable(t *testing.T) {
+	testSetAddressNotEditable(t, newPostgresStore(t))
+}
+
+func TestPostgresDeleteNotEditable(t *testing.T) {
+	testDeleteNotEditable(t, newPostgresStore(t))
+}
+
+func TestPostgresSubscribe(t *testing.T) {
+	testSubscribe(t, newPostgresStore(t))
+}
+
+func TestPostgresSubscribeFilter(t *testing.T) {
+	testSubscribeFilter(t, newPostgresStore(t))
+}
+
+func TestPostgresSubscribeNoHistory(t *testing.T) {
+	testSubscribeNoHistory(t, newPostgresStore(t))
+}
@@ -0,0 +1,358 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/Aleksandr-Osipenko/go-db-sql-final/internal/tracing"
+)
+
+// SQLiteStore реализует ParcelStore поверх SQLite. Изменения статуса и новые
+// посылки фиксируются триггерами schema.sql в таблице-outbox parcel_events;
+// pollEvents опрашивает её для доставки подписчикам Subscribe.
+type SQLiteStore struct {
+	db *sql.DB
+
+	mu       sync.Mutex
+	subs     []*subscription
+	pollStop chan struct{} // не nil, пока фоновый опрос parcel_events запущен
+}
+
+// NewSQLiteStore создаёт ParcelStore поверх SQLite.
+func NewSQLiteStore(db *sql.DB) *SQLiteStore {
+	return &SQLiteStore{db: db}
+}
+
+func (s *SQLiteStore) Add(ctx context.Context, p Parcel) (int, error) {
+	ctx, span := tracing.StartSpanFromContext(ctx, "SQLiteStore.Add",
+		attribute.Int("parcel.client", p.Client),
+		attribute.String("parcel.status", string(p.Status)),
+	)
+	defer span.End()
+
+	// реализуйте добавление строки в таблицу parcel, используйте данные из переменной p
+	result, err := s.db.ExecContext(ctx, "INSERT INTO parcel (client, status, address, created_at) VALUES (?, ?, ?, ?)",
+		p.Client, p.Status, p.Address, p.CreatedAt)
+	if err != nil {
+		return 0, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	// верните идентификатор последней добавленной записи
+	return int(id), nil
+}
+
+func (s *SQLiteStore) Get(ctx context.Context, number int) (Parcel, error) {
+	ctx, span := tracing.StartSpanFromContext(ctx, "SQLiteStore.Get", attribute.Int("parcel.number", number))
+	defer span.End()
+
+	// реализуйте чтение строки по заданному number
+	// здесь из таблицы должна вернуться только одна строка
+	// Используем SQL-запрос для получения конкретной записи по номеру
+	row := s.db.QueryRowContext(ctx, "SELECT * FROM parcel WHERE number = ?", number)
+
+	// заполните объект Parcel данными из таблицы
+	p := Parcel{}
+	err := row.Scan(&p.Number, &p.Client, &p.Status, &p.Address, &p.CreatedAt)
+	if err != nil {
+		return p, err
+	}
+	return p, nil
+}
+
+func (s *SQLiteStore) GetByClient(ctx context.Context, client int) ([]Parcel, error) {
+	ctx, span := tracing.StartSpanFromContext(ctx, "SQLiteStore.GetByClient", attribute.Int("parcel.client", client))
+	defer span.End()
+
+	// реализуйте чтение строк из таблицы parcel по заданному client
+	// здесь из таблицы может вернуться несколько строк
+	// Используем SQL-запрос для получения всех записей для заданного клиента
+	rows, err := s.db.QueryContext(ctx, "SELECT * FROM parcel WHERE client = ?", client)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// заполните срез Parcel данными из таблицы
+	var res []Parcel
+	for rows.Next() {
+		p := Parcel{}
+		err := rows.Scan(&p.Number, &p.Client, &p.Status, &p.Address, &p.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, p)
+	}
+
+	return res, rows.Err()
+}
+
+// SetStatus переводит посылку в новый статус, проверяя, что переход разрешён
+// (registered -> sent -> delivered, без возврата назад). Проверка и обновление
+// выполняются в рамках одной транзакции с BEGIN IMMEDIATE, чтобы исключить
+// гонку между конкурентными переходами одной и той же посылки.
+func (s *SQLiteStore) SetStatus(ctx context.Context, number int, newStatus ParcelStatus) error {
+	ctx, span := tracing.StartSpanFromContext(ctx, "SQLiteStore.SetStatus",
+		attribute.Int("parcel.number", number),
+		attribute.String("parcel.status", string(newStatus)),
+	)
+	defer span.End()
+
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "BEGIN IMMEDIATE"); err != nil {
+		return err
+	}
+
+	var current ParcelStatus
+	err = conn.QueryRowContext(ctx, "SELECT status FROM parcel WHERE number = ?", number).Scan(&current)
+	if err != nil {
+		rollback(ctx, conn)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrParcelNotFound
+		}
+		return err
+	}
+
+	if !canTransition(current, newStatus) {
+		rollback(ctx, conn)
+		return fmt.Errorf("%w: %s -> %s", ErrInvalidStatusTransition, current, newStatus)
+	}
+
+	if _, err := conn.ExecContext(ctx, "UPDATE parcel SET status = ? WHERE number = ?", newStatus, number); err != nil {
+		rollback(ctx, conn)
+		return err
+	}
+
+	return commit(ctx, conn)
+}
+
+// SetAddress меняет адрес посылки, только если она ещё в статусе registered.
+// Проверка и обновление выполняются в рамках одной транзакции с BEGIN
+// IMMEDIATE, как и в SetStatus, — иначе конкурентный SetStatus мог бы
+// перевести посылку в другой статус между проверкой и UPDATE, и запрос
+// молча обновил бы 0 строк.
+func (s *SQLiteStore) SetAddress(ctx context.Context, number int, address string) error {
+	ctx, span := tracing.StartSpanFromContext(ctx, "SQLiteStore.SetAddress", attribute.Int("parcel.number", number))
+	defer span.End()
+
+	return s.withEditableParcel(ctx, number, func(conn *sql.Conn) error {
+		_, err := conn.ExecContext(ctx, "UPDATE parcel SET address = ? WHERE number = ?", address, number)
+		return err
+	})
+}
+
+// Delete удаляет посылку, только если она ещё в статусе registered. Проверка
+// и удаление выполняются в рамках одной транзакции — по тем же причинам, что
+// и в SetAddress.
+func (s *SQLiteStore) Delete(ctx context.Context, number int) error {
+	ctx, span := tracing.StartSpanFromContext(ctx, "SQLiteStore.Delete", attribute.Int("parcel.number", number))
+	defer span.End()
+
+	return s.withEditableParcel(ctx, number, func(conn *sql.Conn) error {
+		_, err := conn.ExecContext(ctx, "DELETE FROM parcel WHERE number = ?", number)
+		return err
+	})
+}
+
+// withEditableParcel открывает транзакцию с BEGIN IMMEDIATE, проверяет, что
+// посылка number существует и находится в статусе registered, и в рамках той
+// же транзакции выполняет do. Так проверка статуса и изменение строки
+// атомарны относительно конкурентного SetStatus.
+func (s *SQLiteStore) withEditableParcel(ctx context.Context, number int, do func(conn *sql.Conn) error) error {
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "BEGIN IMMEDIATE"); err != nil {
+		return err
+	}
+
+	var status ParcelStatus
+	err = conn.QueryRowContext(ctx, "SELECT status FROM parcel WHERE number = ?", number).Scan(&status)
+	if err != nil {
+		rollback(ctx, conn)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrParcelNotFound
+		}
+		return err
+	}
+
+	if status != ParcelStatusRegistered {
+		rollback(ctx, conn)
+		return ErrParcelNotEditable
+	}
+
+	if err := do(conn); err != nil {
+		rollback(ctx, conn)
+		return err
+	}
+
+	return commit(ctx, conn)
+}
+
+// rollback и commit завершают транзакцию BEGIN IMMEDIATE на conn, используя
+// context.WithoutCancel — отвязанный от дедлайна/отмены вызывающей стороны.
+// Выполнять ROLLBACK или COMMIT с исходным ctx небезопасно: ExecContext у
+// modernc.org/sqlite, как только ctx уже завершён, возвращает ctx.Err(), не
+// трогая SQLite, — то есть завершающий запрос молча не выполнится, транзакция
+// останется открытой, а соединение вернётся в пул, всё ещё удерживая блокировку
+// записи.
+func rollback(ctx context.Context, conn *sql.Conn) {
+	conn.ExecContext(context.WithoutCancel(ctx), "ROLLBACK")
+}
+
+func commit(ctx context.Context, conn *sql.Conn) error {
+	_, err := conn.ExecContext(context.WithoutCancel(ctx), "COMMIT")
+	return err
+}
+
+// eventPollInterval — период опроса таблицы parcel_events фоновым поллером.
+const eventPollInterval = 20 * time.Millisecond
+
+// subscription — состояние одного подписчика Subscribe: канал, на который
+// доставляются события, фильтр и позиция последнего доставленного события.
+type subscription struct {
+	ch     chan ParcelEvent
+	filter ParcelEventFilter
+	lastID int64
+}
+
+// Subscribe подписывает вызывающую сторону на события посылок, отобранные
+// filter. Новые посылки и переходы статуса пишутся триггерами SQLite в
+// parcel_events; фоновый поллер вычитывает новые строки и раздаёт их
+// подписчикам. Канал закрывается при отмене ctx.
+//
+// У подписки нет истории: lastID подписчика выставляется на текущий максимум
+// parcel_events ещё до регистрации, поэтому первый же тик поллера разбирает
+// только события, записанные после вызова Subscribe, — так же, как у
+// PostgresStore.Subscribe через LISTEN/NOTIFY.
+func (s *SQLiteStore) Subscribe(ctx context.Context, filter ParcelEventFilter) <-chan ParcelEvent {
+	sub := &subscription{ch: make(chan ParcelEvent, 16), filter: filter}
+
+	var lastID int64
+	if err := s.db.QueryRowContext(ctx, "SELECT COALESCE(MAX(id), 0) FROM parcel_events").Scan(&lastID); err != nil {
+		close(sub.ch)
+		return sub.ch
+	}
+	sub.lastID = lastID
+
+	s.mu.Lock()
+	s.subs = append(s.subs, sub)
+	if s.pollStop == nil {
+		stop := make(chan struct{})
+		s.pollStop = stop
+		go s.pollEvents(stop)
+	}
+	s.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		s.unsubscribe(sub)
+	}()
+
+	return sub.ch
+}
+
+func (s *SQLiteStore) unsubscribe(sub *subscription) {
+	s.mu.Lock()
+	for i, x := range s.subs {
+		if x == sub {
+			s.subs = append(s.subs[:i], s.subs[i+1:]...)
+			close(sub.ch)
+			break
+		}
+	}
+
+	var stop chan struct{}
+	if len(s.subs) == 0 {
+		stop = s.pollStop
+		s.pollStop = nil
+	}
+	s.mu.Unlock()
+
+	if stop != nil {
+		close(stop)
+	}
+}
+
+// pollEvents раз в eventPollInterval вычитывает новые строки parcel_events для
+// каждого подписчика и завершает работу, когда stop закрывается (последний
+// подписчик отписался).
+func (s *SQLiteStore) pollEvents(stop chan struct{}) {
+	ticker := time.NewTicker(eventPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			s.dispatchEvents()
+		}
+	}
+}
+
+func (s *SQLiteStore) dispatchEvents() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, sub := range s.subs {
+		rows, err := s.db.Query(
+			`SELECT id, number, client, old_status, new_status, created_at
+			 FROM parcel_events WHERE id > ? ORDER BY id`, sub.lastID)
+		if err != nil {
+			continue
+		}
+
+		for rows.Next() {
+			var (
+				id                   int64
+				number, client       int
+				oldStatus, newStatus sql.NullString
+				createdAt            string
+			)
+			if err := rows.Scan(&id, &number, &client, &oldStatus, &newStatus, &createdAt); err != nil {
+				continue
+			}
+			sub.lastID = id
+
+			if !sub.filter.matches(client) {
+				continue
+			}
+
+			ts, err := time.Parse(time.RFC3339, createdAt)
+			if err != nil {
+				ts = time.Now().UTC()
+			}
+
+			event := ParcelEvent{
+				Number:    number,
+				OldStatus: ParcelStatus(oldStatus.String),
+				NewStatus: ParcelStatus(newStatus.String),
+				Timestamp: ts,
+			}
+
+			select {
+			case sub.ch <- event:
+			default:
+				// подписчик не успевает вычитывать — не блокируем остальных.
+			}
+		}
+		rows.Close()
+	}
+}
@@ -0,0 +1,70 @@
+package store
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newSQLiteStore открывает отдельную временную БД для теста (t.TempDir
+// гарантирует уникальный каталог на каждый запуск) и применяет к ней schemaSQL,
+// поэтому тесты не делят состояние и не зависят от порядка запуска.
+func newSQLiteStore(t *testing.T) ParcelStore {
+	t.Helper()
+
+	dsn := filepath.Join(t.TempDir(), "tracker.db")
+	db, err := sql.Open("sqlite", dsn)
+	require.NoError(t, err) //тест на ошибку подключения к БД
+	t.Cleanup(func() { db.Close() })
+
+	_, err = db.Exec(schemaSQL)
+	require.NoError(t, err) //тест на ошибку применения схемы
+
+	return NewSQLiteStore(db)
+}
+
+func TestSQLiteAddGetDelete(t *testing.T) {
+	testAddGetDelete(t, newSQLiteStore(t))
+}
+
+func TestSQLiteSetAddress(t *testing.T) {
+	testSetAddress(t, newSQLiteStore(t))
+}
+
+func TestSQLiteSetStatus(t *testing.T) {
+	testSetStatus(t, newSQLiteStore(t))
+}
+
+func TestSQLiteGetByClient(t *testing.T) {
+	testGetByClient(t, newSQLiteStore(t))
+}
+
+func TestSQLiteGetByClientContextCancelled(t *testing.T) {
+	testGetByClientContextCancelled(t, newSQLiteStore(t))
+}
+
+func TestSQLiteSetStatusInvalidTransition(t *testing.T) {
+	testSetStatusInvalidTransition(t, newSQLiteStore(t))
+}
+
+func TestSQLiteSetAddressNotEditable(t *testing.T) {
+	testSetAddressNotEditable(t, newSQLiteStore(t))
+}
+
+func TestSQLiteDeleteNotEditable(t *testing.T) {
+	testDeleteNotEditable(t, newSQLiteStore(t))
+}
+
+func TestSQLiteSubscribe(t *testing.T) {
+	testSubscribe(t, newSQLiteStore(t))
+}
+
+func TestSQLiteSubscribeFilter(t *testing.T) {
+	testSubscribeFilter(t, newSQLiteStore(t))
+}
+
+func TestSQLiteSubscribeNoHistory(t *testing.T) {
+	testSubscribeNoHistory(t, newSQLiteStore(t))
+}
@@ -0,0 +1,55 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// Driver определяет СУБД, на которую настроен ParcelStore.
+type Driver string
+
+const (
+	DriverSQLite   Driver = "sqlite"
+	DriverPostgres Driver = "postgres"
+)
+
+// ParcelStore описывает хранилище посылок, не привязываясь к конкретной СУБД.
+// Каждый метод принимает context.Context, чтобы отмена и дедлайны вызывающей
+// стороны доходили до запросов к БД.
+type ParcelStore interface {
+	Add(ctx context.Context, p Parcel) (int, error)
+	Get(ctx context.Context, number int) (Parcel, error)
+	GetByClient(ctx context.Context, client int) ([]Parcel, error)
+	SetStatus(ctx context.Context, number int, status ParcelStatus) error
+	SetAddress(ctx context.Context, number int, address string) error
+	Delete(ctx context.Context, number int) error
+
+	// Subscribe подписывает вызывающую сторону на события жизненного цикла
+	// посылок, проходящие через filter. Возвращённый канал закрывается, когда
+	// ctx отменяется — отдельного метода Unsubscribe не требуется.
+	Subscribe(ctx context.Context, filter ParcelEventFilter) <-chan ParcelEvent
+}
+
+// Config описывает параметры, необходимые для создания ParcelStore.
+// DSN используется только PostgreSQL-бэкендом для LISTEN/NOTIFY, которому
+// нужно отдельное "сырое" подключение в дополнение к пулу database/sql.
+type Config struct {
+	Driver Driver
+	DB     *sql.DB
+	DSN    string
+}
+
+// NewParcelStore создаёт ParcelStore для указанного в cfg драйвера. Выбор
+// драйвера определяет используемые плейсхолдеры параметров, способ получения
+// идентификатора новой записи и механизм доставки событий Subscribe.
+func NewParcelStore(cfg Config) (ParcelStore, error) {
+	switch cfg.Driver {
+	case DriverSQLite:
+		return NewSQLiteStore(cfg.DB), nil
+	case DriverPostgres:
+		return NewPostgresStore(cfg.DB, cfg.DSN), nil
+	default:
+		return nil, fmt.Errorf("store: unknown driver %q", cfg.Driver)
+	}
+}
@@ -0,0 +1,279 @@
+package store
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Общие тестовые сценарии для ParcelStore. Каждый бэкенд (sqlite_test.go,
+// postgres_test.go) прогоняет их против собственного подключения к БД.
+
+var (
+	// randSource источник псевдо случайных чисел.
+	// Для повышения уникальности в качестве seed
+	// используется текущее время в unix формате (в виде числа)
+	randSource = rand.NewSource(time.Now().UnixNano())
+	// randRange использует randSource для генерации случайных чисел
+	randRange = rand.New(randSource)
+)
+
+// getTestParcel возвращает тестовую посылку
+func getTestParcel() Parcel {
+	return Parcel{
+		Client:    1000,
+		Status:    ParcelStatusRegistered,
+		Address:   "test",
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+}
+
+// testAddGetDelete проверяет добавление, получение и удаление посылки
+func testAddGetDelete(t *testing.T, store ParcelStore) {
+	ctx := context.Background()
+	parcel := getTestParcel()
+
+	// add
+	// добавление новой посылкуи в БД
+	add, err := store.Add(ctx, parcel)
+	require.NoError(t, err)  //тест на ошибку добавления в БД
+	require.NotEmpty(t, add) //тест на наличие идентификатора
+
+	// get
+	// получение только что добавленной посылки
+	get, err := store.Get(ctx, add)
+	parcel.Number = add
+	assert.NoError(t, err)       //тест на ошибку получения только что добавленной посылки
+	assert.Equal(t, parcel, get) //тест на совпадение полей в полученном объекте со значениями полей в переменной parcel
+
+	// delete
+	// удаление добавленной посылки
+	err = store.Delete(ctx, add)
+	require.NoError(t, err) //тест на отсутствие ошибки удаления посылки
+	_, err = store.Get(ctx, add)
+	assert.Error(t, err) //тест на наличие ошибки при получении удалённой посылки
+}
+
+// testSetAddress проверяет обновление адреса
+func testSetAddress(t *testing.T, store ParcelStore) {
+	ctx := context.Background()
+	parcel := getTestParcel()
+
+	// add
+	// добавление новой посылкуи в БД
+	add, err := store.Add(ctx, parcel)
+	require.NoError(t, err)  //тест на ошибку добавления в БД
+	require.NotEmpty(t, add) //тест на наличие идентификатора
+
+	// set address
+	// обновление адреса
+	newAddress := "new test address"
+	err = store.SetAddress(ctx, add, newAddress)
+	require.NoError(t, err) //тест на ошибку обновления адреса
+
+	// check
+	// получение добавленной посылки
+	get, err := store.Get(ctx, add)
+	assert.NoError(t, err)                   //тест на ошибку получения посылки
+	assert.Equal(t, newAddress, get.Address) //сравнение нового адреса посылки с заданным
+}
+
+// testSetStatus проверяет обновление статуса
+func testSetStatus(t *testing.T, store ParcelStore) {
+	ctx := context.Background()
+	parcel := getTestParcel()
+
+	// add
+	// добавление новой посылкуи в БД
+	add, err := store.Add(ctx, parcel)
+	require.NoError(t, err)  //тест на ошибку добавления в БД
+	require.NotEmpty(t, add) //тест на наличие идентификатора
+
+	// set status
+	// обновление статуса
+	newStatus := ParcelStatusSent
+	err = store.SetStatus(ctx, add, newStatus)
+	require.NoError(t, err) //тест на ошибку обновления статуса
+
+	// check
+	// получение добавленной посылки
+	get, err := store.Get(ctx, add)
+	assert.NoError(t, err)                 //тест на ошибку получения посылки
+	assert.Equal(t, newStatus, get.Status) //сравнение нового статуса посылки с заданным
+}
+
+// testSetStatusInvalidTransition проверяет, что запрещённый переход статуса
+// (например, попытка доставить ещё не отправленную посылку) отклоняется.
+func testSetStatusInvalidTransition(t *testing.T, store ParcelStore) {
+	ctx := context.Background()
+	parcel := getTestParcel()
+
+	add, err := store.Add(ctx, parcel)
+	require.NoError(t, err)
+	require.NotEmpty(t, add)
+
+	err = store.SetStatus(ctx, add, ParcelStatusDelivered)
+	assert.ErrorIs(t, err, ErrInvalidStatusTransition)
+
+	// статус посылки не должен был измениться
+	get, err := store.Get(ctx, add)
+	require.NoError(t, err)
+	assert.Equal(t, ParcelStatusRegistered, get.Status)
+}
+
+// testSetAddressNotEditable проверяет, что адрес нельзя изменить у посылки,
+// покинувшей статус registered.
+func testSetAddressNotEditable(t *testing.T, store ParcelStore) {
+	ctx := context.Background()
+	parcel := getTestParcel()
+
+	add, err := store.Add(ctx, parcel)
+	require.NoError(t, err)
+	require.NoError(t, store.SetStatus(ctx, add, ParcelStatusSent))
+
+	err = store.SetAddress(ctx, add, "new address")
+	assert.ErrorIs(t, err, ErrParcelNotEditable)
+}
+
+// testDeleteNotEditable проверяет, что удалить можно только посылку в статусе registered.
+func testDeleteNotEditable(t *testing.T, store ParcelStore) {
+	ctx := context.Background()
+	parcel := getTestParcel()
+
+	add, err := store.Add(ctx, parcel)
+	require.NoError(t, err)
+	require.NoError(t, store.SetStatus(ctx, add, ParcelStatusSent))
+
+	err = store.Delete(ctx, add)
+	assert.ErrorIs(t, err, ErrParcelNotEditable)
+}
+
+// testGetByClient проверяет получение посылок по идентификатору клиента
+func testGetByClient(t *testing.T, store ParcelStore) {
+	ctx := context.Background()
+
+	// задаём всем посылкам один и тот же идентификатор клиента
+	client := randRange.Intn(10_000_000)
+	parcels, parcelMap := seedFixtures(ctx, t, store, client)
+
+	// get by client
+	storedParcels, err := store.GetByClient(ctx, client) // получите список посылок по идентификатору клиента, сохранённого в переменной client
+	require.NoError(t, err)                              // тест на ошибку
+	require.Equal(t, len(parcels), len(storedParcels))   // убедитесь, что количество полученных посылок совпадает с количеством добавленных
+
+	// check
+	for _, parcel := range storedParcels {
+		// в parcelMap лежат добавленные посылки, ключ - идентификатор посылки, значение - сама посылка
+		// убедитесь, что все посылки из storedParcels есть в parcelMap
+		assert.Equal(t, parcelMap[parcel.Number], parcel)
+		// убедитесь, что значения полей полученных посылок заполнены верно
+	}
+}
+
+// testGetByClientContextCancelled проверяет, что отменённый контекст прерывает
+// выполнение GetByClient вместо того, чтобы дождаться ответа от БД.
+func testGetByClientContextCancelled(t *testing.T, store ParcelStore) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := store.GetByClient(ctx, 1000)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+// subscribeTimeout — сколько ждём событие Subscribe в тестах, прежде чем
+// признать его недоставленным.
+const subscribeTimeout = 5 * time.Second
+
+// testSubscribe проверяет, что Add и SetStatus доставляют подписчику
+// соответствующие события, а отмена контекста закрывает канал.
+func testSubscribe(t *testing.T, store ParcelStore) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	parcel := getTestParcel()
+	events := store.Subscribe(ctx, ParcelEventFilter{ClientID: parcel.Client})
+
+	add, err := store.Add(ctx, parcel)
+	require.NoError(t, err)
+	require.NotEmpty(t, add)
+
+	select {
+	case event := <-events:
+		assert.Equal(t, add, event.Number)
+		assert.Empty(t, event.OldStatus)
+		assert.Equal(t, ParcelStatusRegistered, event.NewStatus)
+	case <-time.After(subscribeTimeout):
+		t.Fatal("не дождались события о регистрации посылки")
+	}
+
+	require.NoError(t, store.SetStatus(ctx, add, ParcelStatusSent))
+
+	select {
+	case event := <-events:
+		assert.Equal(t, add, event.Number)
+		assert.Equal(t, ParcelStatusRegistered, event.OldStatus)
+		assert.Equal(t, ParcelStatusSent, event.NewStatus)
+	case <-time.After(subscribeTimeout):
+		t.Fatal("не дождались события о смене статуса")
+	}
+
+	cancel()
+
+	select {
+	case _, ok := <-events:
+		assert.False(t, ok) //канал должен быть закрыт после отмены контекста
+	case <-time.After(subscribeTimeout):
+		t.Fatal("канал не закрылся после отмены контекста")
+	}
+}
+
+// testSubscribeFilter проверяет, что подписчик получает только события своего клиента.
+func testSubscribeFilter(t *testing.T, store ParcelStore) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	other := getTestParcel()
+	other.Client = randRange.Intn(10_000_000) + 1
+
+	parcel := getTestParcel()
+	parcel.Client = other.Client + 1
+
+	events := store.Subscribe(ctx, ParcelEventFilter{ClientID: parcel.Client})
+
+	_, err := store.Add(ctx, other)
+	require.NoError(t, err)
+
+	add, err := store.Add(ctx, parcel)
+	require.NoError(t, err)
+	require.NotEmpty(t, add)
+
+	select {
+	case event := <-events:
+		assert.Equal(t, add, event.Number) //первым и единственным должно прийти событие "своего" клиента
+	case <-time.After(subscribeTimeout):
+		t.Fatal("не дождались события о регистрации посылки")
+	}
+}
+
+// testSubscribeNoHistory проверяет, что у подписки нет истории: события,
+// записанные до вызова Subscribe, новому подписчику не доставляются.
+func testSubscribeNoHistory(t *testing.T, store ParcelStore) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	parcel := getTestParcel()
+	_, err := store.Add(ctx, parcel)
+	require.NoError(t, err)
+
+	events := store.Subscribe(ctx, ParcelEventFilter{ClientID: parcel.Client})
+
+	select {
+	case event, ok := <-events:
+		t.Fatalf("получено событие из истории (ok=%v): %+v", ok, event)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -0,0 +1,24 @@
+// Package tracing собирает общие хелперы для работы с OpenTelemetry трейсингом.
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName используется как имя трейсера для всех спанов пакета store.
+const tracerName = "github.com/Aleksandr-Osipenko/go-db-sql-final/internal/store"
+
+// StartSpanFromContext открывает новый спан с именем name поверх ctx и возвращает
+// обновлённый контекст вместе с самим спаном; вызывающая сторона завершает его
+// через span.End().
+func StartSpanFromContext(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, name)
+	if len(attrs) > 0 {
+		span.SetAttributes(attrs...)
+	}
+	return ctx, span
+}
@@ -1,91 +0,0 @@
-package main
-
-import (
-	"database/sql"
-)
-
-type ParcelStore struct {
-	db *sql.DB
-}
-
-func NewParcelStore(db *sql.DB) ParcelStore {
-	return ParcelStore{db: db}
-}
-
-func (s ParcelStore) Add(p Parcel) (int, error) {
-	// реализуйте добавление строки в таблицу parcel, используйте данные из переменной p
-	result, err := s.db.Exec("INSERT INTO parcel (client, status, address, created_at) VALUES (?, ?, ?, ?)",
-		p.Client, p.Status, p.Address, p.CreatedAt)
-	if err != nil {
-		return 0, err
-	}
-	id, err := result.LastInsertId()
-	if err != nil {
-		return 0, err
-	}
-	// верните идентификатор последней добавленной записи
-	return int(id), nil
-}
-
-func (s ParcelStore) Get(number int) (Parcel, error) {
-	// реализуйте чтение строки по заданному number
-	// здесь из таблицы должна вернуться только одна строка
-	// Используем SQL-запрос для получения конкретной записи по номеру
-	row := s.db.QueryRow("SELECT * FROM parcel WHERE number = ?", number)
-
-	// заполните объект Parcel данными из таблицы
-	p := Parcel{}
-	err := row.Scan(&p.Number, &p.Client, &p.Status, &p.Address, &p.CreatedAt)
-	if err != nil {
-		return p, err
-	}
-	return p, nil
-}
-
-func (s ParcelStore) GetByClient(client int) ([]Parcel, error) {
-	// реализуйте чтение строк из таблицы parcel по заданному client
-	// здесь из таблицы может вернуться несколько строк
-	// Используем SQL-запрос для получения всех записей для заданного клиента
-	rows, err := s.db.Query("SELECT * FROM parcel WHERE client = ?", client)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	// заполните срез Parcel данными из таблицы
-	var res []Parcel
-	for rows.Next() {
-		p := Parcel{}
-		err := rows.Scan(&p.Number, &p.Client, &p.Status, &p.Address, &p.CreatedAt)
-		if err != nil {
-			return nil, err
-		}
-		res = append(res, p)
-	}
-
-	return res, nil
-}
-
-func (s ParcelStore) SetStatus(number int, status string) error {
-	// реализуйте обновление статуса в таблице parcel
-	// Используем SQL-запрос для обновления статуса по номеру
-	_, err := s.db.Exec("UPDATE parcel SET status = ? WHERE number = ?", status, number)
-	return err
-}
-
-func (s ParcelStore) SetAddress(number int, address string) error {
-	// реализуйте обновление адреса в таблице parcel
-	// менять адрес можно только если значение статуса registered
-	// Используем SQL-запрос для обновления адреса по номеру
-	_, err := s.db.Exec("UPDATE parcel SET address = ? WHERE number = ? AND status = 'registered'", address, number)
-
-	return err
-}
-
-func (s ParcelStore) Delete(number int) error {
-	// реализуйте удаление строки из таблицы parcel
-	// удалять строку можно только если значение статуса registered
-	// Используем SQL-запрос для удаления записи по номеру
-	_, err := s.db.Exec("DELETE FROM parcel WHERE number = ? AND status = 'registered'", number)
-	return err
-}